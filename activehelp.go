@@ -0,0 +1,14 @@
+package ishell
+
+import "os"
+
+// activeHelpEnv is the environment variable that, when set to "0", disables
+// ActiveHelp-style hints during tab completion.
+const activeHelpEnv = "ISHELL_ACTIVE_HELP"
+
+// activeHelpEnabledByEnv reports whether ActiveHelp is enabled according to
+// the environment, in the absence of an explicit Shell.ShellActiveHelp
+// setting.
+func activeHelpEnabledByEnv() bool {
+	return os.Getenv(activeHelpEnv) != "0"
+}
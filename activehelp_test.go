@@ -0,0 +1,80 @@
+package ishell
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDoPrintsActiveHelpOnZeroMatches(t *testing.T) {
+	var buf bytes.Buffer
+	sh := NewShell()
+	sh.Writer = &buf
+	sh.AddCmd(&Cmd{
+		Name: "push",
+		ActiveHelp: func(args []string) []string {
+			return []string{"expected: <remote>"}
+		},
+	})
+
+	ic := iCompleter{cmd: sh.rootCmd, shell: sh}
+	ic.Do([]rune("push "), len("push "))
+
+	if got := buf.String(); !strings.Contains(got, "expected: <remote>") {
+		t.Fatalf("Do did not print ActiveHelp hint, got %q", got)
+	}
+}
+
+func TestDoPrintsActiveHelpFromCompleterWithHelp(t *testing.T) {
+	var buf bytes.Buffer
+	sh := NewShell()
+	sh.Writer = &buf
+	sh.AddCmd(&Cmd{
+		Name: "deploy",
+		CompleterWithHelp: func(prefix string, args []string) ([]string, []string) {
+			return nil, []string{"known values: staging, prod"}
+		},
+	})
+
+	ic := iCompleter{cmd: sh.rootCmd, shell: sh}
+	ic.Do([]rune("deploy "), len("deploy "))
+
+	if got := buf.String(); !strings.Contains(got, "known values: staging, prod") {
+		t.Fatalf("Do did not print CompleterWithHelp hint, got %q", got)
+	}
+}
+
+func TestDoSkipsActiveHelpWhenDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	sh := NewShell()
+	sh.Writer = &buf
+	sh.ShellActiveHelp = false
+	sh.AddCmd(&Cmd{
+		Name:       "push",
+		ActiveHelp: func(args []string) []string { return []string{"expected: <remote>"} },
+	})
+
+	ic := iCompleter{cmd: sh.rootCmd, shell: sh}
+	ic.Do([]rune("push "), len("push "))
+
+	if got := buf.String(); got != "" {
+		t.Fatalf("expected no ActiveHelp output when disabled, got %q", got)
+	}
+}
+
+func TestDoSkipsActiveHelpOnSingleMatch(t *testing.T) {
+	var buf bytes.Buffer
+	sh := NewShell()
+	sh.Writer = &buf
+	sh.AddCmd(&Cmd{
+		Name:       "push",
+		ActiveHelp: func(args []string) []string { return []string{"expected: <remote>"} },
+	})
+
+	ic := iCompleter{cmd: sh.rootCmd, shell: sh}
+	ic.Do([]rune("pu"), len("pu"))
+
+	if got := buf.String(); got != "" {
+		t.Fatalf("expected no ActiveHelp output on a single narrowing match, got %q", got)
+	}
+}
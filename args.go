@@ -0,0 +1,89 @@
+package ishell
+
+import "fmt"
+
+// NoArgs returns an error if any arguments are given.
+func NoArgs(c *Context, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unknown command %q for %q", args[0], c.Cmd.Name)
+	}
+	return nil
+}
+
+// ArbitraryArgs never returns an error.
+func ArbitraryArgs(c *Context, args []string) error {
+	return nil
+}
+
+// MinimumNArgs returns a validator that errors if there are fewer than n args.
+func MinimumNArgs(n int) func(c *Context, args []string) error {
+	return func(c *Context, args []string) error {
+		if len(args) < n {
+			return fmt.Errorf("requires at least %d arg(s), only received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// MaximumNArgs returns a validator that errors if there are more than n args.
+func MaximumNArgs(n int) func(c *Context, args []string) error {
+	return func(c *Context, args []string) error {
+		if len(args) > n {
+			return fmt.Errorf("accepts at most %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// ExactArgs returns a validator that errors if there are not exactly n args.
+func ExactArgs(n int) func(c *Context, args []string) error {
+	return func(c *Context, args []string) error {
+		if len(args) != n {
+			return fmt.Errorf("accepts %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// RangeArgs returns a validator that errors if the number of args is not
+// within the inclusive range [min, max].
+func RangeArgs(min, max int) func(c *Context, args []string) error {
+	return func(c *Context, args []string) error {
+		if len(args) < min || len(args) > max {
+			return fmt.Errorf("accepts between %d and %d arg(s), received %d", min, max, len(args))
+		}
+		return nil
+	}
+}
+
+// OnlyValidArgs returns an error if any arg is not present in c.Cmd.ValidArgs.
+func OnlyValidArgs(c *Context, args []string) error {
+	for _, arg := range args {
+		if !stringInSlice(arg, c.Cmd.ValidArgs) {
+			return fmt.Errorf("invalid argument %q for %q", arg, c.Cmd.Name)
+		}
+	}
+	return nil
+}
+
+// MatchAll combines multiple Args validators into one that requires all of
+// them to pass, in order, stopping at the first error.
+func MatchAll(validators ...func(c *Context, args []string) error) func(c *Context, args []string) error {
+	return func(c *Context, args []string) error {
+		for _, v := range validators {
+			if err := v(c, args); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package ishell
+
+import "testing"
+
+func TestNoArgs(t *testing.T) {
+	c := &Context{Cmd: &Cmd{Name: "cmd"}}
+	if err := NoArgs(c, nil); err != nil {
+		t.Fatalf("expected no error for zero args, got %v", err)
+	}
+	if err := NoArgs(c, []string{"extra"}); err == nil {
+		t.Fatal("expected error for extra arg")
+	}
+}
+
+func TestArbitraryArgs(t *testing.T) {
+	c := &Context{Cmd: &Cmd{Name: "cmd"}}
+	if err := ArbitraryArgs(c, []string{"a", "b", "c"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestMinimumNArgs(t *testing.T) {
+	c := &Context{Cmd: &Cmd{Name: "cmd"}}
+	validate := MinimumNArgs(2)
+	if err := validate(c, []string{"a"}); err == nil {
+		t.Fatal("expected error for too few args")
+	}
+	if err := validate(c, []string{"a", "b"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := validate(c, []string{"a", "b", "c"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestMaximumNArgs(t *testing.T) {
+	c := &Context{Cmd: &Cmd{Name: "cmd"}}
+	validate := MaximumNArgs(1)
+	if err := validate(c, []string{"a"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := validate(c, []string{"a", "b"}); err == nil {
+		t.Fatal("expected error for too many args")
+	}
+}
+
+func TestExactArgs(t *testing.T) {
+	c := &Context{Cmd: &Cmd{Name: "cmd"}}
+	validate := ExactArgs(2)
+	if err := validate(c, []string{"a", "b"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := validate(c, []string{"a"}); err == nil {
+		t.Fatal("expected error for too few args")
+	}
+	if err := validate(c, []string{"a", "b", "c"}); err == nil {
+		t.Fatal("expected error for too many args")
+	}
+}
+
+func TestRangeArgs(t *testing.T) {
+	c := &Context{Cmd: &Cmd{Name: "cmd"}}
+	validate := RangeArgs(1, 2)
+	if err := validate(c, nil); err == nil {
+		t.Fatal("expected error for zero args")
+	}
+	if err := validate(c, []string{"a"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := validate(c, []string{"a", "b"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := validate(c, []string{"a", "b", "c"}); err == nil {
+		t.Fatal("expected error for too many args")
+	}
+}
+
+func TestOnlyValidArgs(t *testing.T) {
+	c := &Context{Cmd: &Cmd{Name: "cmd", ValidArgs: []string{"foo", "bar"}}}
+	if err := OnlyValidArgs(c, []string{"foo", "bar"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := OnlyValidArgs(c, []string{"baz"}); err == nil {
+		t.Fatal("expected error for invalid arg")
+	}
+}
+
+func TestMatchAll(t *testing.T) {
+	c := &Context{Cmd: &Cmd{Name: "cmd"}}
+	validate := MatchAll(MinimumNArgs(1), MaximumNArgs(2))
+	if err := validate(c, []string{"a"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := validate(c, nil); err == nil {
+		t.Fatal("expected error from MinimumNArgs")
+	}
+	if err := validate(c, []string{"a", "b", "c"}); err == nil {
+		t.Fatal("expected error from MaximumNArgs")
+	}
+}
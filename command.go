@@ -21,6 +21,25 @@ type Cmd struct {
 	// More descriptive help message for the command.
 	LongHelp string
 
+	// Args validates the arguments the command was invoked with, mirroring
+	// Cobra's PositionalArgs. It runs after FindCmd resolves the command and
+	// before Func, and one of NoArgs, ArbitraryArgs, MinimumNArgs,
+	// MaximumNArgs, ExactArgs, RangeArgs, OnlyValidArgs or MatchAll of them
+	// can be used. If it returns an error, the shell prints the error and
+	// the command's usage instead of running Func.
+	Args func(c *Context, args []string) error
+
+	// ValidArgs is the list of valid non-flag arguments, used by
+	// OnlyValidArgs and, when no other completer is set, to autocomplete
+	// the command's arguments.
+	ValidArgs []string
+
+	// ValidArgsFunction is a dynamic alternative to ValidArgs: given the
+	// already-parsed args and the word being completed, it returns the
+	// valid completions. If set, it takes precedence over ValidArgs for
+	// completion purposes.
+	ValidArgsFunction func(args []string, toComplete string) []string
+
 	// Completer is custom autocomplete for command.
 	// It takes in command arguments and returns
 	// autocomplete options.
@@ -36,6 +55,52 @@ type Cmd struct {
 	// CompleterWithPrefix takes precedence
 	CompleterWithPrefix func(prefix string, args []string) []string
 
+	// CompleterWithDirective is custom autocomplete like CompleterWithPrefix,
+	// but additionally returns a CompDirective controlling how the shell
+	// treats the returned matches (trailing space, filesystem fallback,
+	// extension/directory filtering, ordering).
+	CompleterWithDirective func(prefix string, args []string) ([]string, CompDirective)
+
+	// CompleterWithHelp is custom autocomplete like CompleterWithPrefix, but
+	// also returns ActiveHelp-style hint lines to show alongside the
+	// completion suggestions. If set, it takes precedence over
+	// CompleterWithPrefix and Completer.
+	CompleterWithHelp func(prefix string, args []string) (matches []string, help []string)
+
+	// ActiveHelp returns hint lines (e.g. "expected: <filename>" or
+	// "known values: foo, bar") to show beneath the prompt when tab
+	// completion does not narrow to a single match. Modeled on Cobra's
+	// ActiveHelp. Ignored when CompleterWithHelp is set.
+	ActiveHelp func(args []string) []string
+
+	// Group is the heading this command is clustered under in its
+	// parent's help output. Commands with no Group are listed under
+	// "Additional Commands" when a sibling has a Group; when no sibling
+	// has one, all are listed under a plain "Commands:" heading.
+	Group string
+
+	// Hidden excludes the command from help output and completion. It
+	// remains invocable by name.
+	Hidden bool
+
+	// Deprecated, if non-empty, is printed as a warning the first time the
+	// command is invoked in a session, in place of running it silently.
+	Deprecated string
+
+	// WrapperOf names another registered command (or an external binary
+	// found on $PATH) that this command is a thin wrapper around. When set
+	// and no other Completer is given, the command automatically inherits
+	// the target's completion; see Shell.CompletionForWrapper.
+	WrapperOf string
+
+	// Example is a usage example shown in generated man pages and
+	// Markdown docs (see the ishell/doc subpackage).
+	Example string
+
+	// SeeAlso lists the names of related commands to cross-link in
+	// generated man pages and Markdown docs.
+	SeeAlso []string
+
 	// subcommands.
 	children map[string]*Cmd
 
@@ -64,20 +129,26 @@ func (c *Cmd) DeleteCmd(name string) {
 	delete(c.children, name)
 }
 
-// Children returns the subcommands of c.
+// Children returns the non-hidden subcommands of c.
 func (c *Cmd) Children() []*Cmd {
 	var cmds []*Cmd
 	for _, cmd := range c.children {
+		if cmd.Hidden {
+			continue
+		}
 		cmds = append(cmds, cmd)
 	}
 	sort.Sort(cmdSorter(cmds))
 	return cmds
 }
 
-// OptionalChildren returns the subcommands of c.
+// OptionalChildren returns the non-hidden subcommands of c.
 func (c *Cmd) OptionalChildren() []*Cmd {
 	var cmds []*Cmd
 	for _, cmd := range c.optionalChildren {
+		if cmd.Hidden {
+			continue
+		}
 		cmds = append(cmds, cmd)
 	}
 	sort.Sort(cmdSorter(cmds))
@@ -85,21 +156,27 @@ func (c *Cmd) OptionalChildren() []*Cmd {
 }
 
 func (c *Cmd) hasSubcommand() bool {
-	if len(c.children) > 1 {
+	children := c.Children()
+	if len(children) > 1 {
 		return true
 	}
-	if _, ok := c.children["help"]; !ok {
-		return len(c.children) > 0
+	for _, child := range children {
+		if child.Name != "help" {
+			return true
+		}
 	}
 	return false
 }
 
 func (c *Cmd) hasOptionalSubcommands() bool {
-	if len(c.OptionalChildren()) > 1 {
+	children := c.OptionalChildren()
+	if len(children) > 1 {
 		return true
 	}
-	if _, ok := c.optionalChildren["help"]; !ok {
-		return len(c.optionalChildren) > 0
+	for _, child := range children {
+		if child.Name != "help" {
+			return true
+		}
 	}
 	return false
 }
@@ -121,13 +198,7 @@ func (c Cmd) HelpText() string {
 		p(c.Name, "has no help")
 	}
 	if c.hasSubcommand() {
-		p("Commands:")
-		w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
-		for _, child := range c.Children() {
-			fmt.Fprintf(w, "\t%s\t\t\t%s\n", child.Name, child.Help)
-		}
-		w.Flush()
-		p()
+		printCmds(&b, p, c.Children())
 	}
 	if c.hasOptionalSubcommands() {
 		p("Optional Commands:")
@@ -141,6 +212,61 @@ func (c Cmd) HelpText() string {
 	return b.String()
 }
 
+// printCmds writes children to b, one heading per Cmd.Group, in a
+// table with their Help text. Commands with no Group are listed last, under
+// an "Additional Commands" heading, unless no command in children has a
+// Group at all, in which case a single plain "Commands:" heading is used.
+func printCmds(b *bytes.Buffer, p func(s ...interface{}), children []*Cmd) {
+	grouped := false
+	for _, child := range children {
+		if child.Group != "" {
+			grouped = true
+			break
+		}
+	}
+	if !grouped {
+		p("Commands:")
+		printCmdTable(b, children)
+		p()
+		return
+	}
+
+	var groupNames []string
+	groups := make(map[string][]*Cmd)
+	var ungrouped []*Cmd
+	for _, child := range children {
+		if child.Group == "" {
+			ungrouped = append(ungrouped, child)
+			continue
+		}
+		if _, ok := groups[child.Group]; !ok {
+			groupNames = append(groupNames, child.Group)
+		}
+		groups[child.Group] = append(groups[child.Group], child)
+	}
+	sort.Strings(groupNames)
+
+	for _, name := range groupNames {
+		p(name + ":")
+		printCmdTable(b, groups[name])
+		p()
+	}
+	if len(ungrouped) > 0 {
+		p("Additional Commands:")
+		printCmdTable(b, ungrouped)
+		p()
+	}
+}
+
+// printCmdTable writes cmds as a tab-aligned Name/Help table to b.
+func printCmdTable(b *bytes.Buffer, cmds []*Cmd) {
+	w := tabwriter.NewWriter(b, 0, 4, 2, ' ', 0)
+	for _, cmd := range cmds {
+		fmt.Fprintf(w, "\t%s\t\t\t%s\n", cmd.Name, cmd.Help)
+	}
+	w.Flush()
+}
+
 // findChildCmd returns the subcommand with matching name or alias.
 func (c *Cmd) findChildCmd(name string) *Cmd {
 	// find perfect matches first
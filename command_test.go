@@ -0,0 +1,76 @@
+package ishell
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHelpTextGroupsCommandsByGroup(t *testing.T) {
+	root := &Cmd{}
+	root.AddCmd(&Cmd{Name: "start", Help: "start it", Group: "Lifecycle"})
+	root.AddCmd(&Cmd{Name: "stop", Help: "stop it", Group: "Lifecycle"})
+	root.AddCmd(&Cmd{Name: "version", Help: "print version"})
+
+	text := root.HelpText()
+	lifecycle := strings.Index(text, "Lifecycle:")
+	additional := strings.Index(text, "Additional Commands:")
+	if lifecycle == -1 || additional == -1 {
+		t.Fatalf("expected both group headings in help text, got:\n%s", text)
+	}
+	if lifecycle > additional {
+		t.Fatalf("expected Lifecycle group before Additional Commands, got:\n%s", text)
+	}
+	if !strings.Contains(text, "version") {
+		t.Fatalf("expected ungrouped command in help text, got:\n%s", text)
+	}
+}
+
+func TestHelpTextPlainHeadingWhenNoGroups(t *testing.T) {
+	root := &Cmd{}
+	root.AddCmd(&Cmd{Name: "start", Help: "start it"})
+
+	text := root.HelpText()
+	if !strings.Contains(text, "Commands:") {
+		t.Fatalf("expected plain Commands: heading, got:\n%s", text)
+	}
+	if strings.Contains(text, "Additional Commands:") {
+		t.Fatalf("did not expect Additional Commands: heading, got:\n%s", text)
+	}
+}
+
+func TestChildrenExcludesHidden(t *testing.T) {
+	root := &Cmd{}
+	root.AddCmd(&Cmd{Name: "visible"})
+	root.AddCmd(&Cmd{Name: "secret", Hidden: true})
+
+	children := root.Children()
+	if len(children) != 1 || children[0].Name != "visible" {
+		t.Fatalf("expected only visible command, got %v", children)
+	}
+	if strings.Contains(root.HelpText(), "secret") {
+		t.Fatalf("hidden command leaked into help text:\n%s", root.HelpText())
+	}
+}
+
+func TestProcessWarnsOnceForDeprecatedCmd(t *testing.T) {
+	sh := NewShell()
+	var buf bytes.Buffer
+	sh.Writer = &buf
+	calls := 0
+	sh.AddCmd(&Cmd{
+		Name:       "old",
+		Deprecated: "use new instead",
+		Func:       func(c *Context) { calls++ },
+	})
+
+	sh.Process([]string{"old"})
+	sh.Process([]string{"old"})
+
+	if calls != 2 {
+		t.Fatalf("expected Func to run both times, ran %d", calls)
+	}
+	if n := strings.Count(buf.String(), "deprecated"); n != 1 {
+		t.Fatalf("expected exactly one deprecation warning, got %d in:\n%s", n, buf.String())
+	}
+}
@@ -1,6 +1,10 @@
 package ishell
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/flynn-archive/go-shlex"
@@ -9,13 +13,12 @@ import (
 type iCompleter struct {
 	cmd      *Cmd
 	disabled func() bool
+	shell    *Shell
 }
 
-func (ic iCompleter) Do(line []rune, pos int) (newLine [][]rune, length int) {
-	if ic.disabled != nil && ic.disabled() {
-		return nil, len(line)
-	}
-	var words []string
+// parseLine splits line into the prefix being completed (if any) and the
+// preceding words, the same way Do does.
+func (ic iCompleter) parseLine(line []rune, pos int) (prefix string, words []string) {
 	if w, err := shlex.Split(string(line)); err == nil {
 		words = w
 	} else {
@@ -23,13 +26,35 @@ func (ic iCompleter) Do(line []rune, pos int) (newLine [][]rune, length int) {
 		words = strings.Fields(string(line))
 	}
 
-	var cWords []string
-	prefix := ""
 	if len(words) > 0 && pos > 0 && line[pos-1] != ' ' {
 		prefix = words[len(words)-1]
-		cWords = ic.getWords(prefix, words[:len(words)-1])
-	} else {
-		cWords = ic.getWords(prefix, words)
+		words = words[:len(words)-1]
+	}
+	return prefix, words
+}
+
+func (ic iCompleter) Do(line []rune, pos int) (newLine [][]rune, length int) {
+	if ic.disabled != nil && ic.disabled() {
+		return nil, len(line)
+	}
+	prefix, words := ic.parseLine(line, pos)
+	cWords, directive, hasDirective := ic.getWordsAndDirective(prefix, words)
+
+	if directive&CompError != 0 {
+		return nil, len(prefix)
+	}
+
+	switch {
+	case directive&CompFilterFileExt != 0:
+		cWords = fileCompletions(prefix, cWords, false)
+	case directive&CompFilterDirs != 0:
+		cWords = fileCompletions(prefix, nil, true)
+	case hasDirective && len(cWords) == 0 && directive&CompNoFileComp == 0:
+		cWords = fileCompletions(prefix, nil, false)
+	}
+
+	if directive&CompKeepOrder == 0 {
+		sort.Strings(cWords)
 	}
 
 	var suggestions [][]rune
@@ -38,28 +63,118 @@ func (ic iCompleter) Do(line []rune, pos int) (newLine [][]rune, length int) {
 			suggestions = append(suggestions, []rune(strings.TrimPrefix(w, prefix)))
 		}
 	}
-	if len(suggestions) == 1 && prefix != "" && string(suggestions[0]) == "" {
+	if len(suggestions) == 1 && prefix != "" && string(suggestions[0]) == "" && directive&CompNoSpace == 0 {
 		suggestions = [][]rune{[]rune(" ")}
 	}
+	if len(suggestions) != 1 {
+		ic.printActiveHelp(prefix, words)
+	}
 	return suggestions, len(prefix)
 }
 
-func (ic iCompleter) getWords(prefix string, w []string) (s []string) {
+// printActiveHelp writes any ActiveHelp hint lines for prefix/words to the
+// shell's Writer (os.Stdout if no shell is set), so they show up beneath the
+// prompt the next time the line is redrawn. Called from Do whenever
+// completion does not narrow to a single match, including zero matches.
+func (ic iCompleter) printActiveHelp(prefix string, words []string) {
+	lines := ic.activeHelp(prefix, words)
+	if len(lines) == 0 {
+		return
+	}
+	if ic.shell != nil {
+		fmt.Fprintln(ic.shell.writer(), strings.Join(lines, "\n"))
+		return
+	}
+	fmt.Fprintln(os.Stdout, strings.Join(lines, "\n"))
+}
+
+// getWordsAndDirective is getWords, renamed to make the directive/hasDirective
+// results it carries explicit at call sites that care about them (Do). It is
+// an alias rather than a separate implementation so the CompleterWithDirective
+// handling lives in exactly one place.
+func (ic iCompleter) getWordsAndDirective(prefix string, w []string) (matches []string, directive CompDirective, hasDirective bool) {
+	return ic.getWords(prefix, w)
+}
+
+// fileCompletions lists filesystem entries whose path has the given prefix,
+// optionally restricted to the given extensions or to directories only.
+func fileCompletions(prefix string, exts []string, dirsOnly bool) []string {
+	dir, base := filepath.Split(prefix)
+	lookIn := dir
+	if lookIn == "" {
+		lookIn = "."
+	}
+	entries, err := os.ReadDir(lookIn)
+	if err != nil {
+		return nil
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, base) {
+			continue
+		}
+		if dirsOnly && !entry.IsDir() {
+			continue
+		}
+		if len(exts) > 0 && !entry.IsDir() {
+			matched := false
+			for _, ext := range exts {
+				if strings.HasSuffix(name, ext) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		full := dir + name
+		if entry.IsDir() {
+			full += string(os.PathSeparator)
+		}
+		matches = append(matches, full)
+	}
+	return matches
+}
+
+// getWords resolves the command for w and returns its completion matches,
+// along with the CompDirective from CompleterWithDirective when the
+// resolved command (or, for an invalid optional-arg value, the offending
+// optional command) actually has one set. hasDirective is false for plain
+// Completer/CompleterWithPrefix/subcommand completions, so callers can tell
+// "no directive was returned" apart from "a directive of zero was returned".
+func (ic iCompleter) getWords(prefix string, w []string) (s []string, directive CompDirective, hasDirective bool) {
 	cmd, optCmdValueMap, args := ic.cmd.FindCmd(w)
 
 	for optCmd, value := range optCmdValueMap {
-		if !optCmd.IsValid(value) {
+		if valid, _ := optCmd.IsValid(value); !valid {
+			if optCmd.CompleterWithHelp != nil {
+				matches, _ := optCmd.CompleterWithHelp(prefix, []string{value})
+				return matches, 0, false
+			}
+			if optCmd.CompleterWithDirective != nil {
+				matches, directive := optCmd.CompleterWithDirective(prefix, []string{value})
+				return matches, directive, true
+			}
 			if optCmd.CompleterWithPrefix != nil {
-				return optCmd.CompleterWithPrefix(prefix, []string{value})
+				return optCmd.CompleterWithPrefix(prefix, []string{value}), 0, false
 			}
 			if optCmd.Completer != nil {
-				return optCmd.Completer([]string{value})
+				return optCmd.Completer([]string{value}), 0, false
 			}
-			for k := range optCmd.children {
+			for k, child := range optCmd.children {
+				if child.Hidden {
+					continue
+				}
 				s = append(s, k)
-				return s
+				return s, 0, false
 			}
-			for k := range cmd.optionalChildren {
+			for k, child := range cmd.optionalChildren {
+				if child.Hidden {
+					continue
+				}
 				s = append(s, k)
 			}
 		}
@@ -68,18 +183,76 @@ func (ic iCompleter) getWords(prefix string, w []string) (s []string) {
 	if cmd == nil {
 		cmd, args = ic.cmd, w
 	}
+	if cmd.CompleterWithHelp != nil {
+		matches, _ := cmd.CompleterWithHelp(prefix, args)
+		return matches, 0, false
+	}
+	if cmd.CompleterWithDirective != nil {
+		matches, directive := cmd.CompleterWithDirective(prefix, args)
+		return matches, directive, true
+	}
 	if cmd.CompleterWithPrefix != nil {
-		return cmd.CompleterWithPrefix(prefix, args)
+		return cmd.CompleterWithPrefix(prefix, args), 0, false
+	}
+	if cmd.Completer != nil {
+		return cmd.Completer(args), 0, false
+	}
+	if cmd.ValidArgsFunction != nil {
+		return cmd.ValidArgsFunction(args, prefix), 0, false
 	}
-	if cmd.Completer != nil  {
-		return cmd.Completer(args)
+	if len(cmd.ValidArgs) > 0 {
+		return cmd.ValidArgs, 0, false
+	}
+	if cmd.WrapperOf != "" {
+		return completeWrapperTargetIn(ic.cmd, cmd.WrapperOf, prefix, args), 0, false
 	}
 
-	for k := range cmd.children {
+	for k, child := range cmd.children {
+		if child.Hidden {
+			continue
+		}
 		s = append(s, k)
 	}
-	for k := range cmd.optionalChildren {
+	for k, child := range cmd.optionalChildren {
+		if child.Hidden {
+			continue
+		}
 		s = append(s, k)
 	}
-	return s
+	return s, 0, false
+}
+
+// ActiveHelp returns the ActiveHelp-style hint lines for the given input
+// line, for shells with ShellActiveHelp enabled to display beneath the
+// prompt before redrawing, e.g. when completion does not narrow to a single
+// match. It mirrors the prefix/args parsing used by Do.
+func (ic iCompleter) ActiveHelp(line []rune, pos int) []string {
+	prefix, args := ic.parseLine(line, pos)
+	return ic.activeHelp(prefix, args)
+}
+
+// activeHelp resolves the command for args and returns its ActiveHelp (or
+// CompleterWithHelp) hint lines, unless ActiveHelp has been disabled via the
+// shell or the ISHELL_ACTIVE_HELP environment variable.
+func (ic iCompleter) activeHelp(prefix string, args []string) []string {
+	if ic.shell != nil {
+		if !ic.shell.ShellActiveHelp {
+			return nil
+		}
+	} else if !activeHelpEnabledByEnv() {
+		return nil
+	}
+
+	cmd, _, remArgs := ic.cmd.FindCmd(args)
+	if cmd == nil {
+		cmd, remArgs = ic.cmd, args
+	}
+	if cmd.CompleterWithHelp != nil {
+		_, help := cmd.CompleterWithHelp(prefix, remArgs)
+		return help
+	}
+	if cmd.ActiveHelp != nil {
+		return cmd.ActiveHelp(remArgs)
+	}
+	return nil
 }
@@ -0,0 +1,119 @@
+package ishell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// completeCmdName is the name of the hidden command generated completion
+// scripts shell out to for dynamic completions, following Cobra's
+// completion protocol.
+const completeCmdName = "__complete"
+
+// Complete returns the completion matches for args, treating the last
+// element as the prefix being completed and keeping only candidates that
+// start with it, as tab completion inside the interactive shell does.
+func (s *Shell) Complete(args []string) []string {
+	ic := iCompleter{cmd: s.rootCmd, shell: s}
+	prefix := ""
+	if len(args) > 0 {
+		prefix = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+	words, _, _ := ic.getWords(prefix, args)
+	var matches []string
+	for _, w := range words {
+		if strings.HasPrefix(w, prefix) {
+			matches = append(matches, w)
+		}
+	}
+	return matches
+}
+
+// registerCompleteCmd installs the __complete command used by the scripts
+// generated by GenBashCompletion and friends. It is idempotent.
+func (s *Shell) registerCompleteCmd() {
+	if _, ok := s.rootCmd.children[completeCmdName]; ok {
+		return
+	}
+	s.AddCmd(&Cmd{
+		Name:   completeCmdName,
+		Help:   "list completions for the preceding arguments (used by shell completion scripts)",
+		Hidden: true,
+		Func: func(c *Context) {
+			for _, m := range c.Shell.Complete(c.Args) {
+				fmt.Fprintln(c.Shell.writer(), m)
+			}
+		},
+	})
+}
+
+// progName returns the host program's executable name, as used in the
+// generated completion scripts.
+func progName() string {
+	return filepath.Base(os.Args[0])
+}
+
+// GenBashCompletion writes a bash completion script for the host program to
+// w. The script shells out to the program's __complete command for
+// dynamic completions.
+func (s *Shell) GenBashCompletion(w io.Writer) error {
+	s.registerCompleteCmd()
+	prog := progName()
+	_, err := fmt.Fprintf(w, `# bash completion for %[1]s
+_%[1]s_complete() {
+    local cur words
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    words=("${COMP_WORDS[@]:1:COMP_CWORD}")
+    COMPREPLY=($(compgen -W "$(%[1]s %[2]s "${words[@]}")" -- "$cur"))
+}
+complete -F _%[1]s_complete %[1]s
+`, prog, completeCmdName)
+	return err
+}
+
+// GenZshCompletion writes a zsh completion script for the host program to w.
+func (s *Shell) GenZshCompletion(w io.Writer) error {
+	s.registerCompleteCmd()
+	prog := progName()
+	_, err := fmt.Fprintf(w, `#compdef %[1]s
+_%[1]s() {
+    local -a matches
+    matches=("${(@f)$(%[1]s %[2]s "${words[@][2,-1]}")}")
+    compadd -a matches
+}
+compdef _%[1]s %[1]s
+`, prog, completeCmdName)
+	return err
+}
+
+// GenFishCompletion writes a fish completion script for the host program to w.
+func (s *Shell) GenFishCompletion(w io.Writer) error {
+	s.registerCompleteCmd()
+	prog := progName()
+	_, err := fmt.Fprintf(w, `function __%[1]s_complete
+    %[1]s %[2]s (commandline -opc) (commandline -ct)
+end
+complete -c %[1]s -f -a '(__%[1]s_complete)'
+`, prog, completeCmdName)
+	return err
+}
+
+// GenPowerShellCompletion writes a PowerShell completion script for the host
+// program to w.
+func (s *Shell) GenPowerShellCompletion(w io.Writer) error {
+	s.registerCompleteCmd()
+	prog := progName()
+	_, err := fmt.Fprintf(w, `Register-ArgumentCompleter -Native -CommandName %[1]s -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $words = $commandAst.CommandElements[1..($commandAst.CommandElements.Count - 1)] | ForEach-Object { $_.ToString() }
+    & %[1]s %[2]s @words $wordToComplete | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`, prog, completeCmdName)
+	return err
+}
@@ -0,0 +1,56 @@
+package ishell
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestShellComplete(t *testing.T) {
+	sh := NewShell()
+	sh.AddCmd(&Cmd{Name: "push"})
+	sh.AddCmd(&Cmd{Name: "pull"})
+
+	matches := sh.Complete([]string{"pu"})
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %v", matches)
+	}
+}
+
+func TestGenBashCompletionReferencesCompleteCmd(t *testing.T) {
+	sh := NewShell()
+	var buf bytes.Buffer
+	if err := sh.GenBashCompletion(&buf); err != nil {
+		t.Fatalf("GenBashCompletion: %v", err)
+	}
+	if !strings.Contains(buf.String(), completeCmdName) {
+		t.Fatalf("bash script does not reference %s: %s", completeCmdName, buf.String())
+	}
+	registered, ok := sh.rootCmd.children[completeCmdName]
+	if !ok {
+		t.Fatalf("GenBashCompletion did not register the %s command", completeCmdName)
+	}
+	if !registered.Hidden {
+		t.Fatalf("expected %s command to be Hidden", completeCmdName)
+	}
+	if strings.Contains(sh.rootCmd.HelpText(), completeCmdName) {
+		t.Fatalf("expected %s to be excluded from help text", completeCmdName)
+	}
+}
+
+func TestGenZshFishPowerShellCompletionReferenceCompleteCmd(t *testing.T) {
+	sh := NewShell()
+	for _, gen := range []func(w *bytes.Buffer) error{
+		func(w *bytes.Buffer) error { return sh.GenZshCompletion(w) },
+		func(w *bytes.Buffer) error { return sh.GenFishCompletion(w) },
+		func(w *bytes.Buffer) error { return sh.GenPowerShellCompletion(w) },
+	} {
+		var buf bytes.Buffer
+		if err := gen(&buf); err != nil {
+			t.Fatalf("completion generator: %v", err)
+		}
+		if !strings.Contains(buf.String(), completeCmdName) {
+			t.Fatalf("script does not reference %s: %s", completeCmdName, buf.String())
+		}
+	}
+}
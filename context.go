@@ -0,0 +1,13 @@
+package ishell
+
+// Context is passed to a Cmd's Func and Args validator, giving access to
+// the resolved command and the arguments it was invoked with.
+type Context struct {
+	// Cmd is the command being executed.
+	Cmd *Cmd
+	// Args are the command's arguments, with the command name and any
+	// subcommand names already consumed.
+	Args []string
+	// Shell is the Shell the command was dispatched from.
+	Shell *Shell
+}
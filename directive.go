@@ -0,0 +1,33 @@
+package ishell
+
+// CompDirective is a bitmask returned by a CompleterWithDirective,
+// instructing the shell how to treat the accompanying matches. Modeled on
+// Cobra's ShellCompDirective.
+type CompDirective int
+
+const (
+	// CompNoSpace instructs the shell not to append a trailing space after
+	// a single completion match.
+	CompNoSpace CompDirective = 1 << iota
+
+	// CompNoFileComp instructs the shell not to fall back to filesystem
+	// path completion when zero matches are returned.
+	CompNoFileComp
+
+	// CompFilterFileExt instructs the shell to treat the returned matches
+	// as a list of file extensions and complete filesystem paths filtered
+	// to those extensions.
+	CompFilterFileExt
+
+	// CompFilterDirs instructs the shell to complete filesystem paths
+	// restricted to directories.
+	CompFilterDirs
+
+	// CompKeepOrder instructs the shell to preserve the order the matches
+	// were returned in, instead of sorting them alphabetically.
+	CompKeepOrder
+
+	// CompError indicates the completer encountered an error; the shell
+	// should not attempt any further completion for this invocation.
+	CompError
+)
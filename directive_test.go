@@ -0,0 +1,138 @@
+package ishell
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestDoHonorsCompFilterFileExt(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.go", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := &Cmd{}
+	root.AddCmd(&Cmd{
+		Name: "open",
+		CompleterWithDirective: func(prefix string, args []string) ([]string, CompDirective) {
+			return []string{".txt"}, CompFilterFileExt
+		},
+	})
+	ic := iCompleter{cmd: root}
+
+	prefix := dir + string(os.PathSeparator)
+	matches, directive, hasDirective := ic.getWordsAndDirective(prefix, []string{"open"})
+	if !hasDirective {
+		t.Fatal("expected hasDirective to be true")
+	}
+	if directive&CompFilterFileExt == 0 {
+		t.Fatalf("expected CompFilterFileExt directive, got %v", directive)
+	}
+
+	files := fileCompletions(prefix, matches, false)
+	var names []string
+	for _, f := range files {
+		names = append(names, filepath.Base(f))
+	}
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "a.txt" || names[1] != "c.txt" {
+		t.Fatalf("expected only .txt files, got %v", names)
+	}
+}
+
+func TestDoHonorsCompFilterDirs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "subdir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	matches := fileCompletions(filepath.Join(dir, "")+string(os.PathSeparator), nil, true)
+	if len(matches) != 1 || filepath.Base(filepath.Clean(matches[0])) != "subdir" {
+		t.Fatalf("expected only subdir, got %v", matches)
+	}
+}
+
+func TestDoSortsSuggestionsUnlessCompKeepOrder(t *testing.T) {
+	root := &Cmd{}
+	root.AddCmd(&Cmd{
+		Name: "pick",
+		CompleterWithDirective: func(prefix string, args []string) ([]string, CompDirective) {
+			return []string{"zebra", "alpha", "mango"}, CompKeepOrder
+		},
+	})
+	ic := iCompleter{cmd: root}
+
+	matches, directive, hasDirective := ic.getWordsAndDirective("", []string{"pick"})
+	if !hasDirective {
+		t.Fatal("expected hasDirective to be true")
+	}
+	if directive&CompKeepOrder == 0 {
+		t.Fatalf("expected CompKeepOrder directive, got %v", directive)
+	}
+	if matches[0] != "zebra" {
+		t.Fatalf("expected order preserved, got %v", matches)
+	}
+
+	suggestions, _ := ic.Do([]rune("pick "), len("pick "))
+	if len(suggestions) != 3 || string(suggestions[0]) != "zebra" {
+		t.Fatalf("expected Do to keep completer order under CompKeepOrder, got %v", suggestions)
+	}
+
+	root.AddCmd(&Cmd{
+		Name: "sorted",
+		CompleterWithDirective: func(prefix string, args []string) ([]string, CompDirective) {
+			return []string{"zebra", "alpha", "mango"}, CompNoFileComp
+		},
+	})
+	suggestions, _ = ic.Do([]rune("sorted "), len("sorted "))
+	if len(suggestions) != 3 || string(suggestions[0]) != "alpha" || string(suggestions[2]) != "zebra" {
+		t.Fatalf("expected Do to sort suggestions without CompKeepOrder, got %v", suggestions)
+	}
+}
+
+func TestDoReturnsNoSuggestionsOnCompError(t *testing.T) {
+	root := &Cmd{}
+	root.AddCmd(&Cmd{
+		Name: "broken",
+		CompleterWithDirective: func(prefix string, args []string) ([]string, CompDirective) {
+			return nil, CompError
+		},
+	})
+	ic := iCompleter{cmd: root}
+
+	suggestions, _ := ic.Do([]rune("broken "), len("broken "))
+	if len(suggestions) != 0 {
+		t.Fatalf("expected no suggestions on CompError, got %v", suggestions)
+	}
+}
+
+func TestDoDoesNotFileCompleteCommandsWithoutADirective(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "stray.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	root := &Cmd{}
+	root.AddCmd(&Cmd{Name: "push"})
+	ic := iCompleter{cmd: root}
+
+	suggestions, _ := ic.Do([]rune("push "), len("push "))
+	if len(suggestions) != 0 {
+		t.Fatalf("expected no file-completion fallback for a command with no directive, got %v", suggestions)
+	}
+}
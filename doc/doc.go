@@ -0,0 +1,43 @@
+package doc
+
+import (
+	"strings"
+
+	"github.com/hlokavarapu/ishell"
+)
+
+// cmdPath returns the full command path for cmd, ancestors followed by
+// cmd.Name, mirroring Cobra's Command.CommandPath.
+func cmdPath(ancestors []string, cmd *ishell.Cmd) []string {
+	return append(append([]string{}, ancestors...), cmd.Name)
+}
+
+// cmdSlug joins a command path into the filename-safe slug used for
+// generated doc filenames and cross-links, e.g. []string{"user", "delete"}
+// becomes "user-delete". It keeps same-named subcommands registered under
+// different parents from colliding.
+func cmdSlug(path []string) string {
+	return strings.Join(path, "-")
+}
+
+// buildSlugIndex walks sh's entire command tree and returns a map from bare
+// command name to its full slug, used to resolve Cmd.SeeAlso cross-links
+// (which are recorded as bare names). When multiple commands share a name,
+// the one encountered first in a depth-first walk wins (each command before
+// its subcommands, and a command's whole subtree before its next sibling).
+func buildSlugIndex(sh *ishell.Shell) map[string]string {
+	index := make(map[string]string)
+	var walk func(cmds []*ishell.Cmd, ancestors []string)
+	walk = func(cmds []*ishell.Cmd, ancestors []string) {
+		for _, cmd := range cmds {
+			path := cmdPath(ancestors, cmd)
+			if _, ok := index[cmd.Name]; !ok {
+				index[cmd.Name] = cmdSlug(path)
+			}
+			walk(cmd.Children(), path)
+			walk(cmd.OptionalChildren(), path)
+		}
+	}
+	walk(sh.Commands(), nil)
+	return index
+}
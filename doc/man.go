@@ -0,0 +1,120 @@
+// Package doc generates offline documentation (man pages and Markdown) from
+// a Shell's registered command tree.
+package doc
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hlokavarapu/ishell"
+)
+
+// GenManHeader is used to fill out the header of generated man pages. Unset
+// fields fall back to sensible defaults.
+type GenManHeader struct {
+	Title   string
+	Section string
+	Source  string
+	Manual  string
+	Date    *time.Time
+}
+
+func (hdr *GenManHeader) fill() {
+	if hdr.Section == "" {
+		hdr.Section = "1"
+	}
+	if hdr.Date == nil {
+		now := time.Now()
+		hdr.Date = &now
+	}
+}
+
+// GenManTree generates a man page for every command in sh's tree, writing
+// one file per command into dir; no page is written for the shell itself. Filenames are based on each
+// command's full path (e.g. "user-delete.1"), so subcommands sharing a name
+// under different parents do not collide.
+func GenManTree(sh *ishell.Shell, hdr *GenManHeader, dir string) error {
+	if hdr == nil {
+		hdr = &GenManHeader{}
+	}
+	hdr.fill()
+	slugs := buildSlugIndex(sh)
+	for _, cmd := range sh.Commands() {
+		if err := genManCmd(cmd, nil, hdr, dir, slugs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func genManCmd(cmd *ishell.Cmd, ancestors []string, hdr *GenManHeader, dir string, slugs map[string]string) error {
+	path := cmdPath(ancestors, cmd)
+	for _, child := range cmd.Children() {
+		if err := genManCmd(child, path, hdr, dir, slugs); err != nil {
+			return err
+		}
+	}
+	for _, child := range cmd.OptionalChildren() {
+		if err := genManCmd(child, path, hdr, dir, slugs); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(filepath.Join(dir, cmdSlug(path)+"."+hdr.Section))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeManPage(f, cmd, path, hdr, slugs)
+}
+
+func writeManPage(w io.Writer, cmd *ishell.Cmd, path []string, hdr *GenManHeader, slugs map[string]string) error {
+	title := hdr.Title
+	if title == "" {
+		title = cmd.Name
+	}
+	fmt.Fprintf(w, ".TH \"%s\" \"%s\" \"%s\" \"%s\" \"%s\"\n.SH NAME\n%s",
+		strings.ToUpper(title), hdr.Section, hdr.Date.Format("Jan 2006"), hdr.Source, hdr.Manual, cmd.Name)
+	if cmd.Help != "" {
+		fmt.Fprintf(w, " \\- %s", cmd.Help)
+	}
+	fmt.Fprint(w, "\n")
+
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(w, ".SH ALIASES\n%s\n", strings.Join(cmd.Aliases, ", "))
+	}
+	if cmd.LongHelp != "" {
+		fmt.Fprintf(w, ".SH DESCRIPTION\n%s\n", cmd.LongHelp)
+	}
+	if cmd.Example != "" {
+		fmt.Fprintf(w, ".SH EXAMPLE\n%s\n", cmd.Example)
+	}
+	if children := cmd.Children(); len(children) > 0 {
+		fmt.Fprint(w, ".SH SUBCOMMANDS\n")
+		for _, child := range children {
+			fmt.Fprintf(w, ".TP\n%s\n%s\n", child.Name, child.Help)
+		}
+	}
+	if optional := cmd.OptionalChildren(); len(optional) > 0 {
+		fmt.Fprint(w, ".SH OPTIONAL SUBCOMMANDS\n")
+		for _, child := range optional {
+			fmt.Fprintf(w, ".TP\n%s\n%s\n", child.Name, child.Help)
+		}
+	}
+	if len(cmd.SeeAlso) > 0 {
+		var links []string
+		for _, name := range cmd.SeeAlso {
+			slug := name
+			if s, ok := slugs[name]; ok {
+				slug = s
+			}
+			links = append(links, fmt.Sprintf(".BR %s (%s)", slug, hdr.Section))
+		}
+		fmt.Fprintf(w, ".SH SEE ALSO\n%s\n", strings.Join(links, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hlokavarapu/ishell"
+)
+
+func TestGenManTreeAvoidsSameNameCollision(t *testing.T) {
+	sh := ishell.NewShell()
+	user := &ishell.Cmd{Name: "user", Help: "manage users"}
+	user.AddCmd(&ishell.Cmd{Name: "delete", Help: "delete a user"})
+	sh.AddCmd(user)
+
+	project := &ishell.Cmd{Name: "project", Help: "manage projects"}
+	project.AddCmd(&ishell.Cmd{Name: "delete", Help: "delete a project"})
+	sh.AddCmd(project)
+
+	dir := t.TempDir()
+	if err := GenManTree(sh, nil, dir); err != nil {
+		t.Fatalf("GenManTree: %v", err)
+	}
+
+	userDelete, err := os.ReadFile(filepath.Join(dir, "user-delete.1"))
+	if err != nil {
+		t.Fatalf("reading user-delete.1: %v", err)
+	}
+	projectDelete, err := os.ReadFile(filepath.Join(dir, "project-delete.1"))
+	if err != nil {
+		t.Fatalf("reading project-delete.1: %v", err)
+	}
+
+	if !strings.Contains(string(userDelete), "delete a user") {
+		t.Fatalf("user-delete.1 missing its own help text:\n%s", userDelete)
+	}
+	if !strings.Contains(string(projectDelete), "delete a project") {
+		t.Fatalf("project-delete.1 missing its own help text:\n%s", projectDelete)
+	}
+}
+
+func TestGenManTreeSeeAlsoUsesFullPathSlug(t *testing.T) {
+	sh := ishell.NewShell()
+	user := &ishell.Cmd{Name: "user", Help: "manage users"}
+	user.AddCmd(&ishell.Cmd{Name: "delete", Help: "delete a user", SeeAlso: []string{"create"}})
+	user.AddCmd(&ishell.Cmd{Name: "create", Help: "create a user"})
+	sh.AddCmd(user)
+
+	dir := t.TempDir()
+	if err := GenManTree(sh, nil, dir); err != nil {
+		t.Fatalf("GenManTree: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "user-delete.1"))
+	if err != nil {
+		t.Fatalf("reading user-delete.1: %v", err)
+	}
+	if !strings.Contains(string(content), ".BR user-create (1)") {
+		t.Fatalf("expected SEE ALSO to reference user-create, got:\n%s", content)
+	}
+}
+
+func TestGenManTreeIncludesAliasesAndOptionalChildren(t *testing.T) {
+	sh := ishell.NewShell()
+	user := &ishell.Cmd{Name: "user", Help: "manage users", Aliases: []string{"u", "usr"}}
+	user.AddOptionalCmd(&ishell.Cmd{Name: "verbose", Help: "enable verbose output"})
+	sh.AddCmd(user)
+
+	dir := t.TempDir()
+	if err := GenManTree(sh, nil, dir); err != nil {
+		t.Fatalf("GenManTree: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "user.1"))
+	if err != nil {
+		t.Fatalf("reading user.1: %v", err)
+	}
+	if !strings.Contains(string(content), "u, usr") {
+		t.Fatalf("expected aliases in man page, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "OPTIONAL SUBCOMMANDS") || !strings.Contains(string(content), "verbose") {
+		t.Fatalf("expected optional subcommand listed, got:\n%s", content)
+	}
+	if _, err := os.ReadFile(filepath.Join(dir, "user-verbose.1")); err != nil {
+		t.Fatalf("expected a man page generated for the optional subcommand: %v", err)
+	}
+}
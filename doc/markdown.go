@@ -0,0 +1,91 @@
+package doc
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hlokavarapu/ishell"
+)
+
+// GenMarkdownTree generates a Markdown file for every command in sh's tree,
+// writing one file per command into dir; no file is written for the shell
+// itself. Filenames are based on each
+// command's full path (e.g. "user-delete.md"), so subcommands sharing a
+// name under different parents do not collide.
+func GenMarkdownTree(sh *ishell.Shell, dir string) error {
+	slugs := buildSlugIndex(sh)
+	for _, cmd := range sh.Commands() {
+		if err := genMarkdownCmd(cmd, nil, dir, slugs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func genMarkdownCmd(cmd *ishell.Cmd, ancestors []string, dir string, slugs map[string]string) error {
+	path := cmdPath(ancestors, cmd)
+	for _, child := range cmd.Children() {
+		if err := genMarkdownCmd(child, path, dir, slugs); err != nil {
+			return err
+		}
+	}
+	for _, child := range cmd.OptionalChildren() {
+		if err := genMarkdownCmd(child, path, dir, slugs); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(filepath.Join(dir, cmdSlug(path)+".md"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeMarkdown(f, cmd, path, slugs)
+}
+
+func writeMarkdown(w io.Writer, cmd *ishell.Cmd, path []string, slugs map[string]string) error {
+	fmt.Fprintf(w, "## %s\n\n", cmd.Name)
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(w, "Aliases: %s\n\n", strings.Join(cmd.Aliases, ", "))
+	}
+	if cmd.Help != "" {
+		fmt.Fprintf(w, "%s\n\n", cmd.Help)
+	}
+	if cmd.LongHelp != "" {
+		fmt.Fprintf(w, "%s\n\n", cmd.LongHelp)
+	}
+	if cmd.Example != "" {
+		fmt.Fprintf(w, "### Example\n\n```\n%s\n```\n\n", cmd.Example)
+	}
+	if children := cmd.Children(); len(children) > 0 {
+		fmt.Fprint(w, "### Subcommands\n\n")
+		for _, child := range children {
+			childSlug := cmdSlug(cmdPath(path, child))
+			fmt.Fprintf(w, "* [%s](%s.md)\t - %s\n", child.Name, childSlug, child.Help)
+		}
+		fmt.Fprintln(w)
+	}
+	if optional := cmd.OptionalChildren(); len(optional) > 0 {
+		fmt.Fprint(w, "### Optional Subcommands\n\n")
+		for _, child := range optional {
+			childSlug := cmdSlug(cmdPath(path, child))
+			fmt.Fprintf(w, "* [%s](%s.md)\t - %s\n", child.Name, childSlug, child.Help)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(cmd.SeeAlso) > 0 {
+		fmt.Fprint(w, "### See Also\n\n")
+		for _, name := range cmd.SeeAlso {
+			slug := name
+			if s, ok := slugs[name]; ok {
+				slug = s
+			}
+			fmt.Fprintf(w, "* [%s](%s.md)\n", name, slug)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hlokavarapu/ishell"
+)
+
+func TestGenMarkdownTreeAvoidsSameNameCollision(t *testing.T) {
+	sh := ishell.NewShell()
+	user := &ishell.Cmd{Name: "user", Help: "manage users"}
+	user.AddCmd(&ishell.Cmd{Name: "delete", Help: "delete a user"})
+	sh.AddCmd(user)
+
+	project := &ishell.Cmd{Name: "project", Help: "manage projects"}
+	project.AddCmd(&ishell.Cmd{Name: "delete", Help: "delete a project"})
+	sh.AddCmd(project)
+
+	dir := t.TempDir()
+	if err := GenMarkdownTree(sh, dir); err != nil {
+		t.Fatalf("GenMarkdownTree: %v", err)
+	}
+
+	userDelete, err := os.ReadFile(filepath.Join(dir, "user-delete.md"))
+	if err != nil {
+		t.Fatalf("reading user-delete.md: %v", err)
+	}
+	projectDelete, err := os.ReadFile(filepath.Join(dir, "project-delete.md"))
+	if err != nil {
+		t.Fatalf("reading project-delete.md: %v", err)
+	}
+
+	if !strings.Contains(string(userDelete), "delete a user") {
+		t.Fatalf("user-delete.md missing its own help text:\n%s", userDelete)
+	}
+	if !strings.Contains(string(projectDelete), "delete a project") {
+		t.Fatalf("project-delete.md missing its own help text:\n%s", projectDelete)
+	}
+}
+
+func TestGenMarkdownTreeSubcommandLinksUseFullPath(t *testing.T) {
+	sh := ishell.NewShell()
+	user := &ishell.Cmd{Name: "user", Help: "manage users"}
+	user.AddCmd(&ishell.Cmd{Name: "delete", Help: "delete a user"})
+	sh.AddCmd(user)
+
+	dir := t.TempDir()
+	if err := GenMarkdownTree(sh, dir); err != nil {
+		t.Fatalf("GenMarkdownTree: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "user.md"))
+	if err != nil {
+		t.Fatalf("reading user.md: %v", err)
+	}
+	if !strings.Contains(string(content), "(user-delete.md)") {
+		t.Fatalf("expected subcommand link to user-delete.md, got:\n%s", content)
+	}
+}
+
+func TestGenMarkdownTreeIncludesAliasesAndOptionalChildren(t *testing.T) {
+	sh := ishell.NewShell()
+	user := &ishell.Cmd{Name: "user", Help: "manage users", Aliases: []string{"u", "usr"}}
+	user.AddOptionalCmd(&ishell.Cmd{Name: "verbose", Help: "enable verbose output"})
+	sh.AddCmd(user)
+
+	dir := t.TempDir()
+	if err := GenMarkdownTree(sh, dir); err != nil {
+		t.Fatalf("GenMarkdownTree: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "user.md"))
+	if err != nil {
+		t.Fatalf("reading user.md: %v", err)
+	}
+	if !strings.Contains(string(content), "Aliases: u, usr") {
+		t.Fatalf("expected aliases in markdown, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "Optional Subcommands") || !strings.Contains(string(content), "(user-verbose.md)") {
+		t.Fatalf("expected optional subcommand link, got:\n%s", content)
+	}
+	if _, err := os.ReadFile(filepath.Join(dir, "user-verbose.md")); err != nil {
+		t.Fatalf("expected a markdown page generated for the optional subcommand: %v", err)
+	}
+}
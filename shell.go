@@ -0,0 +1,93 @@
+package ishell
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Shell manages a tree of registered commands.
+//
+// This is a minimal Shell, covering only the state needed by features built
+// on top of Cmd and the completer.
+type Shell struct {
+	rootCmd *Cmd
+
+	// Writer is where ActiveHelp hints, command errors and usage are
+	// printed. Defaults to os.Stdout.
+	Writer io.Writer
+
+	// ShellActiveHelp toggles ActiveHelp-style hints during tab completion.
+	// It defaults to the value of the ISHELL_ACTIVE_HELP environment
+	// variable (disabled when that variable is set to "0").
+	ShellActiveHelp bool
+
+	// deprecationWarned tracks which deprecated commands have already
+	// printed their warning this session.
+	deprecationWarned map[*Cmd]bool
+}
+
+// NewShell creates a Shell with an empty root command.
+func NewShell() *Shell {
+	return &Shell{
+		rootCmd:           &Cmd{},
+		Writer:            os.Stdout,
+		ShellActiveHelp:   activeHelpEnabledByEnv(),
+		deprecationWarned: make(map[*Cmd]bool),
+	}
+}
+
+// AddCmd adds cmd as a top level command.
+func (s *Shell) AddCmd(cmd *Cmd) {
+	s.rootCmd.AddCmd(cmd)
+}
+
+// Commands returns the shell's top level commands.
+func (s *Shell) Commands() []*Cmd {
+	return s.rootCmd.Children()
+}
+
+// AddCmdGroup registers cmds as top level commands, clustered under the
+// group heading name in help output.
+func (s *Shell) AddCmdGroup(name string, cmds ...*Cmd) {
+	for _, cmd := range cmds {
+		cmd.Group = name
+		s.AddCmd(cmd)
+	}
+}
+
+// writer returns s.Writer, falling back to os.Stdout if unset.
+func (s *Shell) writer() io.Writer {
+	if s.Writer != nil {
+		return s.Writer
+	}
+	return os.Stdout
+}
+
+// Process resolves the Cmd for args, validates them against Cmd.Args (when
+// set), and invokes its Func. If Args returns an error, the error and the
+// command's usage are printed instead of running Func.
+func (s *Shell) Process(args []string) {
+	cmd, _, remArgs := s.rootCmd.FindCmd(args)
+	if cmd == nil {
+		cmd, remArgs = s.rootCmd, args
+	}
+	c := &Context{Cmd: cmd, Args: remArgs, Shell: s}
+	if cmd.Args != nil {
+		if err := cmd.Args(c, remArgs); err != nil {
+			fmt.Fprintln(s.writer(), err)
+			fmt.Fprint(s.writer(), cmd.HelpText())
+			return
+		}
+	}
+	if cmd.Deprecated != "" && !s.deprecationWarned[cmd] {
+		fmt.Fprintf(s.writer(), "Command %q is deprecated: %s\n", cmd.Name, cmd.Deprecated)
+		if s.deprecationWarned == nil {
+			s.deprecationWarned = make(map[*Cmd]bool)
+		}
+		s.deprecationWarned[cmd] = true
+	}
+	if cmd.Func != nil {
+		cmd.Func(c)
+	}
+}
@@ -0,0 +1,74 @@
+package ishell
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// CompletionForWrapper returns a CompleterWithPrefix-compatible function
+// that delegates completion to the command registered under target in s's
+// command tree, or to an external binary on $PATH by that name if no such
+// command is registered. It is applied automatically to commands with
+// Cmd.WrapperOf set, but can also be assigned directly, e.g. for wrappers
+// not part of the command tree.
+func (s *Shell) CompletionForWrapper(target string) func(prefix string, args []string) []string {
+	return func(prefix string, args []string) []string {
+		return completeWrapperTargetIn(s.rootCmd, target, prefix, args)
+	}
+}
+
+// completeWrapperTargetIn resolves target against root's command tree; if
+// found, completion is delegated to it (recursively handling subcommands
+// and optional children the same way getWords would). Otherwise target is
+// treated as an external binary on $PATH and asked for completions using
+// the __complete protocol.
+func completeWrapperTargetIn(root *Cmd, target, prefix string, args []string) []string {
+	if root != nil {
+		if t := findCmdByName(root, target); t != nil {
+			matches, _, _ := iCompleter{cmd: t}.getWords(prefix, args)
+			return matches
+		}
+	}
+	return completeExternalTarget(target, prefix, args)
+}
+
+// findCmdByName searches cmd's subtree (children and optional children,
+// recursively) for a Cmd matching name or one of its aliases.
+func findCmdByName(cmd *Cmd, name string) *Cmd {
+	if cmd.Name == name || stringInSlice(name, cmd.Aliases) {
+		return cmd
+	}
+	for _, child := range cmd.children {
+		if found := findCmdByName(child, name); found != nil {
+			return found
+		}
+	}
+	for _, child := range cmd.optionalChildren {
+		if found := findCmdByName(child, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// completeExternalTarget shells out to an external binary on $PATH using
+// the __complete completion protocol and returns the parsed matches. It
+// returns nil if target cannot be found or run.
+func completeExternalTarget(target, prefix string, args []string) []string {
+	path, err := exec.LookPath(target)
+	if err != nil {
+		return nil
+	}
+	cmdArgs := append(append([]string{completeCmdName}, args...), prefix)
+	out, err := exec.Command(path, cmdArgs...).Output()
+	if err != nil {
+		return nil
+	}
+	var matches []string
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if line != "" {
+			matches = append(matches, line)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,44 @@
+package ishell
+
+import "testing"
+
+func TestCompletionForWrapperDelegatesToRegisteredCommand(t *testing.T) {
+	sh := NewShell()
+	sh.AddCmd(&Cmd{
+		Name:      "real",
+		Completer: func(args []string) []string { return []string{"alpha", "beta"} },
+	})
+
+	completer := sh.CompletionForWrapper("real")
+	matches := completer("", nil)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches from registered command, got %v", matches)
+	}
+}
+
+func TestCompletionForWrapperFallsBackToExternalBinary(t *testing.T) {
+	sh := NewShell()
+
+	completer := sh.CompletionForWrapper("a-binary-that-does-not-exist")
+	matches := completer("", nil)
+	if matches != nil {
+		t.Fatalf("expected nil matches for nonexistent target, got %v", matches)
+	}
+}
+
+func TestWrapperOfAutoWiresCompletionToRegisteredCommand(t *testing.T) {
+	sh := NewShell()
+	sh.AddCmd(&Cmd{
+		Name:      "real",
+		Completer: func(args []string) []string { return []string{"alpha", "beta"} },
+	})
+	sh.AddCmd(&Cmd{
+		Name:      "alias-for-real",
+		WrapperOf: "real",
+	})
+
+	matches := sh.Complete([]string{"alias-for-real", ""})
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches delegated via WrapperOf, got %v", matches)
+	}
+}